@@ -0,0 +1,170 @@
+package v1alpha1
+
+import (
+	"time"
+
+	v2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AutoscalingType is whether a container's resource is scaled horizontally
+// (via the HPA) or vertically (via Tortoise's own VPA-like recommender).
+type AutoscalingType string
+
+const (
+	AutoscalingTypeHorizontal AutoscalingType = "Horizontal"
+	AutoscalingTypeVertical   AutoscalingType = "Vertical"
+)
+
+// TortoisePhase is where a Tortoise is in its reconcile lifecycle.
+type TortoisePhase string
+
+const (
+	TortoisePhaseWorking      TortoisePhase = "Working"
+	TortoisePhaseEmergency    TortoisePhase = "Emergency"
+	TortoisePhaseBackToNormal TortoisePhase = "BackToNormal"
+)
+
+// TortoiseUpdateMode controls how much Tortoise is allowed to touch the HPA
+// it manages.
+type TortoiseUpdateMode string
+
+const (
+	// TortoiseUpdateModeOff is advisory only: Tortoise still computes
+	// recommendations and writes them to Status, but never touches the HPA
+	// itself, including adopting a pre-existing one. If the HPA does not
+	// exist yet, Tortoise still creates it.
+	TortoiseUpdateModeOff TortoiseUpdateMode = "Off"
+	// TortoiseUpdateModeInitialize seeds a pre-existing HPA with Tortoise's
+	// recommendation exactly once, then behaves like TortoiseUpdateModeOff
+	// from then on, leaving the user in control.
+	TortoiseUpdateModeInitialize TortoiseUpdateMode = "Initialize"
+	// TortoiseUpdateModeUpdate has Tortoise keep the HPA in sync with its
+	// recommendation on every reconcile.
+	TortoiseUpdateModeUpdate TortoiseUpdateMode = "Update"
+)
+
+// TargetRefs identifies the workload and HPA a Tortoise manages.
+type TargetRefs struct {
+	// DeploymentName is kept for backward compatibility with Tortoises
+	// written before scale targets were generalized beyond Deployment; new
+	// manifests should prefer ScaleTargetRef.
+	DeploymentName string `json:"deploymentName,omitempty"`
+	// ScaleTargetRef points at any workload exposing the /scale
+	// subresource (Deployment, StatefulSet, or a CRD).
+	ScaleTargetRef              *corev1.TypedLocalObjectReference `json:"scaleTargetRef,omitempty"`
+	HorizontalPodAutoscalerName *string                           `json:"horizontalPodAutoscalerName,omitempty"`
+}
+
+// ContainerResourcePolicy configures, per container, which resources are
+// scaled horizontally vs vertically.
+type ContainerResourcePolicy struct {
+	ContainerName     string                                 `json:"containerName"`
+	AutoscalingPolicy map[corev1.ResourceName]AutoscalingType `json:"autoscalingPolicy,omitempty"`
+}
+
+// ReplicasRecommendation is one [From,To) hour slot on a given weekday.
+type ReplicasRecommendation struct {
+	From    int          `json:"from"`
+	To      int          `json:"to"`
+	WeekDay time.Weekday `json:"weekday"`
+	Value   int32        `json:"value"`
+}
+
+// TortoiseSpec defines the desired state of Tortoise.
+type TortoiseSpec struct {
+	TargetRefs     TargetRefs                `json:"targetRefs"`
+	ResourcePolicy []ContainerResourcePolicy `json:"resourcePolicy,omitempty"`
+
+	// UpdateMode controls how much Tortoise is allowed to touch the HPA it
+	// manages. When unset, Tortoise behaves as with TortoiseUpdateModeUpdate.
+	// +optional
+	// +kubebuilder:validation:Enum=Off;Initialize;Update
+	UpdateMode TortoiseUpdateMode `json:"updateMode,omitempty"`
+
+	// MetricsSource selects which MetricsSourceProvider this Tortoise's HPA
+	// uses, overriding the controller-wide default. One of
+	// "datadog-external", "prometheus-external", "container-resource".
+	// +optional
+	MetricsSource string `json:"metricsSource,omitempty"`
+	// MetricsSourcePrometheusNameTemplate is the PromQL name template used
+	// when MetricsSource is "prometheus-external"; must contain the literal
+	// substring "CONTAINER".
+	// +optional
+	MetricsSourcePrometheusNameTemplate string `json:"metricsSourcePrometheusNameTemplate,omitempty"`
+
+	// BehaviorTemplate overrides the controller-wide default HPA scaling
+	// behavior for the HPA this Tortoise manages. Leave nil to use the
+	// controller-wide default.
+	// +optional
+	BehaviorTemplate *v2.HorizontalPodAutoscalerBehavior `json:"behaviorTemplate,omitempty"`
+}
+
+// HPATargetUtilizationRecommendationPerContainer is the recommended target
+// utilization for every horizontally-scaled resource of one container.
+type HPATargetUtilizationRecommendationPerContainer struct {
+	ContainerName     string                         `json:"containerName"`
+	TargetUtilization map[corev1.ResourceName]int32 `json:"targetUtilization,omitempty"`
+}
+
+// HorizontalRecommendations holds the time-sliced replica schedule and the
+// per-container target utilizations Tortoise has computed.
+type HorizontalRecommendations struct {
+	TargetUtilizations []HPATargetUtilizationRecommendationPerContainer `json:"targetUtilizations,omitempty"`
+	MaxReplicas        []ReplicasRecommendation                        `json:"maxReplicas,omitempty"`
+	MinReplicas        []ReplicasRecommendation                        `json:"minReplicas,omitempty"`
+
+	// LastAppliedMinReplicas/LastAppliedMaxReplicas record the min/max
+	// replicas tortoise last actually wrote to the HPA, so
+	// getReplicasRecommendation has something to fall back to when the
+	// schedule in MinReplicas/MaxReplicas has a gap for the current time.
+	// +optional
+	LastAppliedMinReplicas *int32 `json:"lastAppliedMinReplicas,omitempty"`
+	// +optional
+	LastAppliedMaxReplicas *int32 `json:"lastAppliedMaxReplicas,omitempty"`
+}
+
+// Recommendations is the top-level container for every kind of
+// recommendation Tortoise computes.
+type Recommendations struct {
+	Horizontal HorizontalRecommendations `json:"horizontal,omitempty"`
+}
+
+// TargetsStatus records the names of the objects Tortoise manages on behalf
+// of this Tortoise.
+type TargetsStatus struct {
+	HorizontalPodAutoscaler string `json:"horizontalPodAutoscaler,omitempty"`
+}
+
+// TortoiseStatus defines the observed state of Tortoise.
+type TortoiseStatus struct {
+	TortoisePhase   TortoisePhase   `json:"tortoisePhase,omitempty"`
+	Targets         TargetsStatus   `json:"targets,omitempty"`
+	Recommendations Recommendations `json:"recommendations,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// Tortoise is the Schema for the tortoises API.
+type Tortoise struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TortoiseSpec   `json:"spec,omitempty"`
+	Status TortoiseStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TortoiseList contains a list of Tortoise.
+type TortoiseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Tortoise `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Tortoise{}, &TortoiseList{})
+}
@@ -0,0 +1,14 @@
+// Package annotation holds the annotation keys Tortoise reads and writes on
+// the objects it manages.
+package annotation
+
+const (
+	// HPAContainerBasedCPUExternalMetricNamePrefixAnnotation is the prefix
+	// datadogExternalProvider uses to build the name of the CPU External
+	// metric it expects DatadogMetric to publish for a given container.
+	HPAContainerBasedCPUExternalMetricNamePrefixAnnotation = "tortoise.autoscaling.mercari.com/hpa-container-based-cpu-external-metric-name-prefix"
+	// HPAContainerBasedMemoryExternalMetricNamePrefixAnnotation is the
+	// memory equivalent of
+	// HPAContainerBasedCPUExternalMetricNamePrefixAnnotation.
+	HPAContainerBasedMemoryExternalMetricNamePrefixAnnotation = "tortoise.autoscaling.mercari.com/hpa-container-based-memory-external-metric-name-prefix"
+)
@@ -0,0 +1,90 @@
+package hpa
+
+import (
+	v2 "k8s.io/api/autoscaling/v2"
+
+	autoscalingv1alpha1 "github.com/mercari/tortoise/api/v1alpha1"
+)
+
+// defaultBehaviorTemplate is used when neither the Tortoise nor the
+// controller-wide default configures one. It preserves the previous
+// hard-coded behavior: scale up by 100% every 60s, scale down by 2% every
+// 90s, no stabilization window.
+func defaultBehaviorTemplate() *v2.HorizontalPodAutoscalerBehavior {
+	return &v2.HorizontalPodAutoscalerBehavior{
+		ScaleUp: &v2.HPAScalingRules{
+			Policies: []v2.HPAScalingPolicy{
+				{
+					Type:          v2.PercentScalingPolicy,
+					Value:         100,
+					PeriodSeconds: 60,
+				},
+			},
+		},
+		ScaleDown: &v2.HPAScalingRules{
+			Policies: []v2.HPAScalingPolicy{
+				{
+					Type:          v2.PercentScalingPolicy,
+					Value:         2,
+					PeriodSeconds: 90,
+				},
+			},
+		},
+	}
+}
+
+// behaviorTemplateForTortoise resolves the HorizontalPodAutoscalerBehavior a
+// newly-created HPA should start with: the Tortoise's own
+// Spec.BehaviorTemplate wins, falling back to the controller-wide default
+// configured via New(), falling back to defaultBehaviorTemplate().
+func behaviorTemplateForTortoise(tortoise *autoscalingv1alpha1.Tortoise, controllerDefault *v2.HorizontalPodAutoscalerBehavior) *v2.HorizontalPodAutoscalerBehavior {
+	if tortoise.Spec.BehaviorTemplate != nil {
+		return tortoise.Spec.BehaviorTemplate.DeepCopy()
+	}
+	if controllerDefault != nil {
+		return controllerDefault.DeepCopy()
+	}
+	return defaultBehaviorTemplate()
+}
+
+// disabledSelectPolicy is shared to avoid allocating a new pointer every
+// time applyEmergencyBehaviorOverride runs.
+var disabledSelectPolicy = v2.DisabledPolicySelect
+
+// applyEmergencyBehaviorOverride forces ScaleDown.SelectPolicy to Disabled
+// so that, on top of MinReplicas being pinned to MaxReplicas, the HPA can't
+// reap pods mid-incident through a scale-down policy racing the next
+// reconcile.
+func applyEmergencyBehaviorOverride(hpa *v2.HorizontalPodAutoscaler) {
+	if hpa.Spec.Behavior == nil {
+		hpa.Spec.Behavior = &v2.HorizontalPodAutoscalerBehavior{}
+	}
+	if hpa.Spec.Behavior.ScaleDown == nil {
+		hpa.Spec.Behavior.ScaleDown = &v2.HPAScalingRules{}
+	}
+	hpa.Spec.Behavior.ScaleDown.SelectPolicy = &disabledSelectPolicy
+}
+
+// clearEmergencyBehaviorOverride undoes applyEmergencyBehaviorOverride once
+// the Tortoise has left TortoisePhaseEmergency. It only acts when
+// ScaleDown.SelectPolicy is currently Disabled, i.e. the override actually
+// looks applied; a user (or a TortoiseUpdateModeUpdate consumer) who set
+// their own SelectPolicy directly on the HPA is left alone. When it does
+// act, it restores SelectPolicy to whatever the template from
+// behaviorTemplateForTortoise specifies (nil unless that template sets one)
+// rather than leaving it pinned to Disabled forever.
+func clearEmergencyBehaviorOverride(hpa *v2.HorizontalPodAutoscaler, tortoise *autoscalingv1alpha1.Tortoise, controllerDefault *v2.HorizontalPodAutoscalerBehavior) {
+	if hpa.Spec.Behavior == nil || hpa.Spec.Behavior.ScaleDown == nil || hpa.Spec.Behavior.ScaleDown.SelectPolicy == nil {
+		return
+	}
+	if *hpa.Spec.Behavior.ScaleDown.SelectPolicy != disabledSelectPolicy {
+		return
+	}
+
+	template := behaviorTemplateForTortoise(tortoise, controllerDefault)
+	var selectPolicy *v2.ScalingPolicySelect
+	if template.ScaleDown != nil {
+		selectPolicy = template.ScaleDown.SelectPolicy
+	}
+	hpa.Spec.Behavior.ScaleDown.SelectPolicy = selectPolicy
+}
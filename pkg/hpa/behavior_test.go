@@ -0,0 +1,78 @@
+package hpa
+
+import (
+	"testing"
+
+	v2 "k8s.io/api/autoscaling/v2"
+
+	autoscalingv1alpha1 "github.com/mercari/tortoise/api/v1alpha1"
+)
+
+func TestClearEmergencyBehaviorOverride(t *testing.T) {
+	userPolicy := v2.MaxPolicySelect
+
+	cases := map[string]struct {
+		behavior *v2.HorizontalPodAutoscalerBehavior
+		want     *v2.ScalingPolicySelect
+	}{
+		"no behavior yet": {
+			behavior: nil,
+			want:     nil,
+		},
+		"no scaleDown rules yet": {
+			behavior: &v2.HorizontalPodAutoscalerBehavior{},
+			want:     nil,
+		},
+		"selectPolicy unset": {
+			behavior: &v2.HorizontalPodAutoscalerBehavior{ScaleDown: &v2.HPAScalingRules{}},
+			want:     nil,
+		},
+		"user-set selectPolicy is left alone": {
+			behavior: &v2.HorizontalPodAutoscalerBehavior{ScaleDown: &v2.HPAScalingRules{SelectPolicy: &userPolicy}},
+			want:     &userPolicy,
+		},
+		"our disabled override is cleared": {
+			behavior: &v2.HorizontalPodAutoscalerBehavior{ScaleDown: &v2.HPAScalingRules{SelectPolicy: &disabledSelectPolicy}},
+			want:     nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			hpa := &v2.HorizontalPodAutoscaler{Spec: v2.HorizontalPodAutoscalerSpec{Behavior: tc.behavior}}
+			tortoise := &autoscalingv1alpha1.Tortoise{}
+
+			clearEmergencyBehaviorOverride(hpa, tortoise, nil)
+
+			var got *v2.ScalingPolicySelect
+			if hpa.Spec.Behavior != nil && hpa.Spec.Behavior.ScaleDown != nil {
+				got = hpa.Spec.Behavior.ScaleDown.SelectPolicy
+			}
+
+			switch {
+			case tc.want == nil && got != nil:
+				t.Errorf("SelectPolicy = %v, want nil", *got)
+			case tc.want != nil && (got == nil || *got != *tc.want):
+				t.Errorf("SelectPolicy = %v, want %v", got, *tc.want)
+			}
+		})
+	}
+}
+
+// TestApplyThenClearEmergencyBehaviorOverride guards the actual lifecycle:
+// once Emergency applies the override, leaving Emergency must clear it
+// rather than leave ScaleDown disabled forever.
+func TestApplyThenClearEmergencyBehaviorOverride(t *testing.T) {
+	hpa := &v2.HorizontalPodAutoscaler{}
+	tortoise := &autoscalingv1alpha1.Tortoise{}
+
+	applyEmergencyBehaviorOverride(hpa)
+	if *hpa.Spec.Behavior.ScaleDown.SelectPolicy != disabledSelectPolicy {
+		t.Fatalf("applyEmergencyBehaviorOverride did not set SelectPolicy to %v", disabledSelectPolicy)
+	}
+
+	clearEmergencyBehaviorOverride(hpa, tortoise, nil)
+	if hpa.Spec.Behavior.ScaleDown.SelectPolicy != nil {
+		t.Errorf("SelectPolicy = %v after clear, want nil", *hpa.Spec.Behavior.ScaleDown.SelectPolicy)
+	}
+}
@@ -2,9 +2,7 @@ package hpa
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	v1 "k8s.io/api/apps/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -15,7 +13,6 @@ import (
 	"github.com/mercari/tortoise/pkg/annotation"
 
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/klog/v2"
 
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/types"
@@ -29,118 +26,187 @@ type Client struct {
 
 	replicaReductionFactor         float64
 	upperTargetResourceUtilization int32
+	metricsSourceProvider          MetricsSourceProvider
+	defaultBehavior                *v2.HorizontalPodAutoscalerBehavior
 }
 
-func New(c client.Client, replicaReductionFactor float64, upperTargetResourceUtilization int) *Client {
+// New creates a Client. metricsSource and metricsSourcePrometheusNameTemplate
+// configure the controller-wide default MetricsSourceProvider; an individual
+// Tortoise can still opt into a different source via its spec.
+// defaultBehavior is the controller-wide HPA scaling behavior applied to
+// Tortoises that don't set their own Spec.BehaviorTemplate; pass nil to fall
+// back to the built-in default (100%/60s up, 2%/90s down).
+func New(c client.Client, replicaReductionFactor float64, upperTargetResourceUtilization int, metricsSource MetricsSourceType, metricsSourcePrometheusNameTemplate string, defaultBehavior *v2.HorizontalPodAutoscalerBehavior) (*Client, error) {
+	provider, err := NewMetricsSourceProvider(metricsSource, metricsSourcePrometheusNameTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("create default metrics source provider: %w", err)
+	}
+
 	return &Client{
 		c:                              c,
 		replicaReductionFactor:         replicaReductionFactor,
 		upperTargetResourceUtilization: int32(upperTargetResourceUtilization),
-	}
+		metricsSourceProvider:          provider,
+		defaultBehavior:                defaultBehavior,
+	}, nil
 }
 
-func (c *Client) CreateHPAOnTortoise(ctx context.Context, tortoise *autoscalingv1alpha1.Tortoise, dm *v1.Deployment) (*v2.HorizontalPodAutoscaler, *autoscalingv1alpha1.Tortoise, error) {
-	// TODO: make this default HPA spec configurable.
+func (c *Client) CreateHPAOnTortoise(ctx context.Context, tortoise *autoscalingv1alpha1.Tortoise, target ScaleTarget) (_ *v2.HorizontalPodAutoscaler, _ *autoscalingv1alpha1.Tortoise, err error) {
+	start := time.Now()
+	var alreadyExists bool
+	defer func() {
+		observeReconciliation(tortoise.Namespace, tortoise.Name, "create", string(tortoise.Status.TortoisePhase), start, err, alreadyExists)
+	}()
+
+	provider, err := metricsSourceProviderForTortoise(tortoise, c.metricsSourceProvider)
+	if err != nil {
+		return nil, tortoise, fmt.Errorf("resolve metrics source provider: %w", err)
+	}
+
 	hpa := &v2.HorizontalPodAutoscaler{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      *tortoise.Spec.TargetRefs.HorizontalPodAutoscalerName,
-			Namespace: tortoise.Namespace,
-			Annotations: map[string]string{
-				annotation.HPAContainerBasedMemoryExternalMetricNamePrefixAnnotation: fmt.Sprintf("datadogmetric@%s:%s-memory-", tortoise.Namespace, tortoise.Spec.TargetRefs.DeploymentName),
-				annotation.HPAContainerBasedCPUExternalMetricNamePrefixAnnotation:    fmt.Sprintf("datadogmetric@%s:%s-cpu-", tortoise.Namespace, tortoise.Spec.TargetRefs.DeploymentName),
-			},
+			Name:        *tortoise.Spec.TargetRefs.HorizontalPodAutoscalerName,
+			Namespace:   tortoise.Namespace,
+			Annotations: datadogExternalMetricAnnotations(provider, tortoise, target),
 		},
 		Spec: v2.HorizontalPodAutoscalerSpec{
-			ScaleTargetRef: v2.CrossVersionObjectReference{
-				Kind:       "Deployment",
-				Name:       tortoise.Spec.TargetRefs.DeploymentName,
-				APIVersion: "apps/v1",
-			},
-			MinReplicas: pointer.Int32(int32(math.Ceil(float64(dm.Status.Replicas) / 2.0))),
-			MaxReplicas: dm.Status.Replicas * 2,
-			Behavior: &v2.HorizontalPodAutoscalerBehavior{
-				ScaleUp: &v2.HPAScalingRules{
-					Policies: []v2.HPAScalingPolicy{
-						{
-							Type:          v2.PercentScalingPolicy,
-							Value:         100,
-							PeriodSeconds: 60,
-						},
-					},
-				},
-				ScaleDown: &v2.HPAScalingRules{
-					Policies: []v2.HPAScalingPolicy{
-						{
-							Type:          v2.PercentScalingPolicy,
-							Value:         2,
-							PeriodSeconds: 90,
-						},
-					},
-				},
-			},
+			ScaleTargetRef: target.crossVersionObjectReference(),
+			MinReplicas:    pointer.Int32(int32(math.Ceil(float64(target.CurrentReplicas) / 2.0))),
+			MaxReplicas:    target.CurrentReplicas * 2,
+			Behavior:       behaviorTemplateForTortoise(tortoise, c.defaultBehavior),
 		},
 	}
 
 	m := make([]v2.MetricSpec, 0, len(tortoise.Spec.ResourcePolicy))
+	targetUtilizations := map[string]int32{}
 	for _, c := range tortoise.Spec.ResourcePolicy {
 		for r, p := range c.AutoscalingPolicy {
-			value := resourceQuantityPtr(resource.MustParse("50"))
+			value := int32(50)
 			if p != autoscalingv1alpha1.AutoscalingTypeHorizontal {
-				value = resourceQuantityPtr(resource.MustParse("90"))
+				value = 90
 			}
-			externalMetricName, err := externalMetricNameFromAnnotation(hpa, c.ContainerName, r)
+			spec, err := provider.BuildMetricSpec(hpa, c.ContainerName, r, value)
 			if err != nil {
 				return nil, tortoise, err
 			}
-			m = append(m, v2.MetricSpec{
-				Type: v2.ExternalMetricSourceType,
-				External: &v2.ExternalMetricSource{
-					Metric: v2.MetricIdentifier{
-						Name: externalMetricName,
-					},
-					Target: v2.MetricTarget{
-						Type:  v2.ValueMetricType,
-						Value: value,
-					},
-				},
-			})
+			m = append(m, spec)
+			targetUtilizations[managedTargetUtilizationKey(c.ContainerName, r)] = value
 		}
 	}
 	hpa.Spec.Metrics = m
 	tortoise.Status.Targets.HorizontalPodAutoscaler = hpa.Name
 
-	err := c.c.Create(ctx, hpa)
-	if apierrors.IsAlreadyExists(err) {
-		// A user specified the existing HPA.
-		return nil, tortoise, nil
+	createErr := c.c.Create(ctx, hpa)
+	if apierrors.IsAlreadyExists(createErr) {
+		alreadyExists = true
+		var adopted *v2.HorizontalPodAutoscaler
+		adopted, tortoise, err = c.adoptExistingHPA(ctx, tortoise)
+		return adopted, tortoise, err
+	}
+	if createErr != nil {
+		err = createErr
+		return nil, tortoise, err
 	}
 
-	return hpa.DeepCopy(), tortoise, err
+	if err := recordLastApplied(hpa, *hpa.Spec.MinReplicas, hpa.Spec.MaxReplicas, targetUtilizations); err != nil {
+		return nil, tortoise, err
+	}
+	if err := c.c.Update(ctx, hpa); err != nil {
+		return nil, tortoise, fmt.Errorf("record tortoise ownership on created hpa: %w", err)
+	}
+
+	hpaMinReplicas.WithLabelValues(tortoise.Namespace, tortoise.Name).Set(float64(*hpa.Spec.MinReplicas))
+	hpaMaxReplicas.WithLabelValues(tortoise.Namespace, tortoise.Name).Set(float64(hpa.Spec.MaxReplicas))
+
+	return hpa.DeepCopy(), tortoise, nil
 }
 
-func (c *Client) GetHPAOnTortoise(ctx context.Context, tortoise *autoscalingv1alpha1.Tortoise) (*v2.HorizontalPodAutoscaler, error) {
+// adoptExistingHPA handles the case where a user already created the HPA
+// Tortoise was about to manage. Unless the Tortoise is pinned to
+// TortoiseUpdateModeOff, Tortoise records itself as a co-owner by stamping
+// adoptedAnnotation. It deliberately leaves lastAppliedAnnotation untouched:
+// that's reserved for the first real UpdateHPAFromTortoiseRecommendation
+// call, so TortoiseUpdateModeInitialize still seeds its recommendation
+// instead of finding the HPA already "initialized" with zeros.
+func (c *Client) adoptExistingHPA(ctx context.Context, tortoise *autoscalingv1alpha1.Tortoise) (*v2.HorizontalPodAutoscaler, *autoscalingv1alpha1.Tortoise, error) {
+	if tortoise.Spec.UpdateMode == autoscalingv1alpha1.TortoiseUpdateModeOff {
+		return nil, tortoise, nil
+	}
+
 	hpa := &v2.HorizontalPodAutoscaler{}
 	if err := c.c.Get(ctx, types.NamespacedName{Namespace: tortoise.Namespace, Name: *tortoise.Spec.TargetRefs.HorizontalPodAutoscalerName}, hpa); err != nil {
+		return nil, tortoise, fmt.Errorf("get pre-existing hpa to adopt: %w", err)
+	}
+
+	if adoptedByTortoise(hpa) {
+		return hpa, tortoise, nil
+	}
+
+	recordAdopted(hpa)
+	if err := c.c.Update(ctx, hpa); err != nil {
+		return nil, tortoise, fmt.Errorf("record tortoise ownership on pre-existing hpa: %w", err)
+	}
+
+	return hpa, tortoise, nil
+}
+
+func (c *Client) GetHPAOnTortoise(ctx context.Context, tortoise *autoscalingv1alpha1.Tortoise) (_ *v2.HorizontalPodAutoscaler, err error) {
+	start := time.Now()
+	defer func() {
+		observeReconciliation(tortoise.Namespace, tortoise.Name, "get", string(tortoise.Status.TortoisePhase), start, err, false)
+	}()
+
+	hpa := &v2.HorizontalPodAutoscaler{}
+	if err = c.c.Get(ctx, types.NamespacedName{Namespace: tortoise.Namespace, Name: *tortoise.Spec.TargetRefs.HorizontalPodAutoscalerName}, hpa); err != nil {
 		return nil, fmt.Errorf("failed to get hpa on tortoise: %w", err)
 	}
 	return hpa, nil
 }
 
-func (c *Client) UpdateHPAFromTortoiseRecommendation(ctx context.Context, tortoise *autoscalingv1alpha1.Tortoise, now time.Time) (*v2.HorizontalPodAutoscaler, *autoscalingv1alpha1.Tortoise, error) {
+func (c *Client) UpdateHPAFromTortoiseRecommendation(ctx context.Context, tortoise *autoscalingv1alpha1.Tortoise, now time.Time) (_ *v2.HorizontalPodAutoscaler, _ *autoscalingv1alpha1.Tortoise, err error) {
+	start := time.Now()
+	defer func() {
+		observeReconciliation(tortoise.Namespace, tortoise.Name, "update", string(tortoise.Status.TortoisePhase), start, err, false)
+	}()
+
 	hpa := &v2.HorizontalPodAutoscaler{}
-	if err := c.c.Get(ctx, types.NamespacedName{Namespace: tortoise.Namespace, Name: *tortoise.Spec.TargetRefs.HorizontalPodAutoscalerName}, hpa); err != nil {
+	if err = c.c.Get(ctx, types.NamespacedName{Namespace: tortoise.Namespace, Name: *tortoise.Spec.TargetRefs.HorizontalPodAutoscalerName}, hpa); err != nil {
 		return nil, tortoise, fmt.Errorf("failed to get hpa on tortoise: %w", err)
 	}
 
+	if tortoise.Spec.UpdateMode == autoscalingv1alpha1.TortoiseUpdateModeOff {
+		// Advisory only: the recommendation is already in tortoise.Status,
+		// but the user asked us not to touch the HPA they're managing.
+		return hpa, tortoise, nil
+	}
+
+	if tortoise.Spec.UpdateMode == autoscalingv1alpha1.TortoiseUpdateModeInitialize {
+		if _, alreadyInitialized, err := lastAppliedByTortoise(hpa); err != nil {
+			return nil, tortoise, err
+		} else if alreadyInitialized {
+			// Initialize only ever seeds the HPA once; afterwards the user
+			// owns it and we go back to advisory-only.
+			return hpa, tortoise, nil
+		}
+	}
+
+	provider, err := metricsSourceProviderForTortoise(tortoise, c.metricsSourceProvider)
+	if err != nil {
+		return nil, tortoise, fmt.Errorf("resolve metrics source provider: %w", err)
+	}
+
+	targetUtilizations := map[string]int32{}
 	for _, t := range tortoise.Status.Recommendations.Horizontal.TargetUtilizations {
 		for k, r := range t.TargetUtilization {
-			if err := updateHPATargetValue(hpa, t.ContainerName, k, r); err != nil {
+			if err := provider.UpdateTargetValue(hpa, t.ContainerName, k, r); err != nil {
 				return nil, tortoise, fmt.Errorf("update HPA from the recommendation from tortoise")
 			}
+			targetUtilizations[managedTargetUtilizationKey(t.ContainerName, k)] = r
+			hpaTargetUtilization.WithLabelValues(tortoise.Namespace, tortoise.Name, t.ContainerName, string(k)).Set(float64(r))
 		}
 	}
 
-	max, err := getReplicasRecommendation(tortoise.Status.Recommendations.Horizontal.MaxReplicas, now)
+	max, err := getReplicasRecommendation(tortoise.Status.Recommendations.Horizontal.MaxReplicas, now, tortoise.Status.Recommendations.Horizontal.LastAppliedMaxReplicas)
 	if err != nil {
 		return nil, tortoise, fmt.Errorf("get maxReplicas recommendation: %w", err)
 	}
@@ -149,10 +215,14 @@ func (c *Client) UpdateHPAFromTortoiseRecommendation(ctx context.Context, tortoi
 	var min int32
 	switch tortoise.Status.TortoisePhase {
 	case autoscalingv1alpha1.TortoisePhaseEmergency:
-		// when emergency mode, we set the same value on minReplicas.
+		// when emergency mode, we set the same value on minReplicas, and
+		// also disable the scale-down policy so pods can't be reaped
+		// mid-incident.
 		min = max
+		applyEmergencyBehaviorOverride(hpa)
 	case autoscalingv1alpha1.TortoisePhaseBackToNormal:
-		idealMin, err := getReplicasRecommendation(tortoise.Status.Recommendations.Horizontal.MinReplicas, now)
+		clearEmergencyBehaviorOverride(hpa, tortoise, c.defaultBehavior)
+		idealMin, err := getReplicasRecommendation(tortoise.Status.Recommendations.Horizontal.MinReplicas, now, tortoise.Status.Recommendations.Horizontal.LastAppliedMinReplicas)
 		if err != nil {
 			return nil, tortoise, fmt.Errorf("get minReplicas recommendation: %w", err)
 		}
@@ -166,24 +236,31 @@ func (c *Client) UpdateHPAFromTortoiseRecommendation(ctx context.Context, tortoi
 			min = reduced
 		}
 	default:
-		min, err = getReplicasRecommendation(tortoise.Status.Recommendations.Horizontal.MinReplicas, now)
+		clearEmergencyBehaviorOverride(hpa, tortoise, c.defaultBehavior)
+		min, err = getReplicasRecommendation(tortoise.Status.Recommendations.Horizontal.MinReplicas, now, tortoise.Status.Recommendations.Horizontal.LastAppliedMinReplicas)
 		if err != nil {
 			return nil, tortoise, fmt.Errorf("get minReplicas recommendation: %w", err)
 		}
 	}
 	hpa.Spec.MinReplicas = &min
 
-	return hpa, tortoise, c.c.Update(ctx, hpa)
-}
+	if err = recordLastApplied(hpa, min, max, targetUtilizations); err != nil {
+		return nil, tortoise, err
+	}
 
-// getReplicasRecommendation finds the corresponding recommendations.
-func getReplicasRecommendation(recommendations []autoscalingv1alpha1.ReplicasRecommendation, now time.Time) (int32, error) {
-	for _, r := range recommendations {
-		if now.Hour() < r.To && now.Hour() >= r.From && now.Weekday() == r.WeekDay {
-			return r.Value, nil
-		}
+	if err = c.c.Update(ctx, hpa); err != nil {
+		return nil, tortoise, err
 	}
-	return 0, errors.New("no recommendation slot")
+
+	// Persist what we actually applied so a later reconcile can fall back
+	// to it if the schedule temporarily has a gap.
+	tortoise.Status.Recommendations.Horizontal.LastAppliedMinReplicas = &min
+	tortoise.Status.Recommendations.Horizontal.LastAppliedMaxReplicas = &max
+
+	hpaMinReplicas.WithLabelValues(tortoise.Namespace, tortoise.Name).Set(float64(min))
+	hpaMaxReplicas.WithLabelValues(tortoise.Namespace, tortoise.Name).Set(float64(max))
+
+	return hpa, tortoise, nil
 }
 
 func externalMetricNameFromAnnotation(hpa *v2.HorizontalPodAutoscaler, containerName string, k corev1.ResourceName) (string, error) {
@@ -199,49 +276,18 @@ func externalMetricNameFromAnnotation(hpa *v2.HorizontalPodAutoscaler, container
 	return prefix + containerName, nil
 }
 
-func updateHPATargetValue(hpa *v2.HorizontalPodAutoscaler, containerName string, k corev1.ResourceName, targetValue int32) error {
-	for _, m := range hpa.Spec.Metrics {
-		if m.Type != v2.ContainerResourceMetricSourceType {
-			continue
-		}
-
-		if m.ContainerResource == nil {
-			// shouldn't reach here
-			klog.ErrorS(nil, "invalid container resource metric", klog.KObj(hpa))
-			continue
-		}
-
-		if m.ContainerResource.Container != containerName || m.ContainerResource.Name != k || m.ContainerResource.Target.AverageUtilization == nil {
-			continue
-		}
-
-		m.ContainerResource.Target.AverageUtilization = &targetValue
-	}
-
-	externalMetricName, err := externalMetricNameFromAnnotation(hpa, containerName, k)
-	if err != nil {
-		return err
+// datadogExternalMetricAnnotations returns the annotations the Datadog
+// external metrics provider relies on to name its DatadogMetric objects. It
+// returns nil for any other provider, since they don't need them.
+func datadogExternalMetricAnnotations(provider MetricsSourceProvider, tortoise *autoscalingv1alpha1.Tortoise, target ScaleTarget) map[string]string {
+	if _, ok := provider.(datadogExternalProvider); !ok {
+		return nil
 	}
 
-	for _, m := range hpa.Spec.Metrics {
-		if m.Type != v2.ExternalMetricSourceType {
-			continue
-		}
-
-		if m.External == nil {
-			// shouldn't reach here
-			klog.ErrorS(nil, "invalid external metric", klog.KObj(hpa))
-			continue
-		}
-
-		if m.External.Metric.Name != externalMetricName {
-			continue
-		}
-
-		m.External.Target.Value.Set(int64(targetValue))
+	return map[string]string{
+		annotation.HPAContainerBasedMemoryExternalMetricNamePrefixAnnotation: fmt.Sprintf("datadogmetric@%s:%s-memory-", tortoise.Namespace, target.Name),
+		annotation.HPAContainerBasedCPUExternalMetricNamePrefixAnnotation:    fmt.Sprintf("datadogmetric@%s:%s-cpu-", tortoise.Namespace, target.Name),
 	}
-
-	return nil
 }
 
 func resourceQuantityPtr(quantity resource.Quantity) *resource.Quantity {
@@ -0,0 +1,84 @@
+package hpa
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// outcome labels the result of a Client operation for
+// tortoise_hpa_reconciliations_total.
+type outcome string
+
+const (
+	outcomeSuccess       outcome = "success"
+	outcomeError         outcome = "error"
+	outcomeAlreadyExists outcome = "already_exists"
+)
+
+var (
+	// reconciliationsTotal mirrors the upstream HPA controller's
+	// reconciliations_total (see k8s PR #116010), scoped per tortoise and
+	// per Client operation/outcome so a stuck recommendation or a string of
+	// update failures shows up immediately.
+	reconciliationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "tortoise_hpa_reconciliations_total",
+		Help: "Number of times the tortoise controller reconciled an HPA, by operation, tortoise phase and outcome.",
+	}, []string{"namespace", "tortoise_name", "operation", "phase", "outcome"})
+
+	// reconciliationDurationSeconds mirrors the upstream
+	// reconciliation_duration_seconds histogram.
+	reconciliationDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "tortoise_hpa_reconciliation_duration_seconds",
+		Help:    "Time it took the tortoise controller to reconcile an HPA, by operation.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"namespace", "tortoise_name", "operation"})
+
+	// hpaMinReplicas/hpaMaxReplicas let operators alert on a tortoise
+	// stuck at an unexpectedly low/high bound.
+	hpaMinReplicas = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tortoise_hpa_min_replicas",
+		Help: "minReplicas tortoise last set on the HPA it manages.",
+	}, []string{"namespace", "tortoise_name"})
+
+	hpaMaxReplicas = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tortoise_hpa_max_replicas",
+		Help: "maxReplicas tortoise last set on the HPA it manages.",
+	}, []string{"namespace", "tortoise_name"})
+
+	// hpaTargetUtilization reports the per-container target utilization
+	// tortoise last wrote back, one series per container/resource.
+	hpaTargetUtilization = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tortoise_hpa_target_utilization",
+		Help: "Target utilization tortoise last set for a container/resource pair on the HPA it manages.",
+	}, []string{"namespace", "tortoise_name", "container_name", "resource_name"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		reconciliationsTotal,
+		reconciliationDurationSeconds,
+		hpaMinReplicas,
+		hpaMaxReplicas,
+		hpaTargetUtilization,
+	)
+}
+
+// observeReconciliation records a single Client operation's outcome and
+// duration. Call via `defer` with time.Now() captured at entry. alreadyExists
+// lets a caller report the already_exists outcome explicitly: by the time the
+// defer runs, a create that raced with an existing HPA may have gone on to
+// adopt it successfully, so the returned err alone can no longer tell the two
+// cases apart.
+func observeReconciliation(namespace, tortoiseName, operation, phase string, start time.Time, err error, alreadyExists bool) {
+	o := outcomeSuccess
+	if alreadyExists {
+		o = outcomeAlreadyExists
+	} else if err != nil {
+		o = outcomeError
+	}
+
+	reconciliationsTotal.WithLabelValues(namespace, tortoiseName, operation, phase, string(o)).Inc()
+	reconciliationDurationSeconds.WithLabelValues(namespace, tortoiseName, operation).Observe(time.Since(start).Seconds())
+}
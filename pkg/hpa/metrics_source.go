@@ -0,0 +1,189 @@
+package hpa
+
+import (
+	"fmt"
+	"strings"
+
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	corev1 "k8s.io/api/core/v1"
+
+	autoscalingv1alpha1 "github.com/mercari/tortoise/api/v1alpha1"
+)
+
+// MetricsSourceType identifies which backend supplies the metrics that the
+// HPA reads container utilization from.
+type MetricsSourceType string
+
+const (
+	// MetricsSourceDatadog writes External metrics of the form
+	// datadogmetric@ns:deploy-cpu-<container>, backed by the Datadog Cluster
+	// Agent's DatadogMetric CRD.
+	MetricsSourceDatadog MetricsSourceType = "datadog-external"
+	// MetricsSourcePrometheus writes External metrics backed by
+	// prometheus-adapter, using a configurable metric name template.
+	MetricsSourcePrometheus MetricsSourceType = "prometheus-external"
+	// MetricsSourceContainerResource uses the native
+	// v2.ContainerResourceMetricSourceType that graduated to stable in
+	// autoscaling/v2, requiring no external metrics pipeline at all.
+	MetricsSourceContainerResource MetricsSourceType = "container-resource"
+
+	// containerNamePlaceholder is substituted with the container name inside
+	// a MetricsSourcePrometheus name template, e.g.
+	// `container_cpu_usage{container="CONTAINER"}`.
+	containerNamePlaceholder = "CONTAINER"
+)
+
+// MetricsSourceProvider builds the MetricSpecs Tortoise puts on the HPA it
+// manages, and knows how to patch the target value of the metrics it built
+// back when a new recommendation comes in. Each MetricsSourceType gets its
+// own implementation so that CreateHPAOnTortoise and
+// UpdateHPAFromTortoiseRecommendation don't need to know which metrics
+// backend is in use.
+type MetricsSourceProvider interface {
+	// BuildMetricSpec returns the MetricSpec for a single container/resource
+	// pair, or an error if the provider can't represent it.
+	BuildMetricSpec(hpa *v2.HorizontalPodAutoscaler, containerName string, resourceName corev1.ResourceName, targetValue int32) (v2.MetricSpec, error)
+	// UpdateTargetValue patches the target value of the metric this provider
+	// owns in place. It's a no-op if the metric isn't found on the HPA.
+	UpdateTargetValue(hpa *v2.HorizontalPodAutoscaler, containerName string, resourceName corev1.ResourceName, targetValue int32) error
+}
+
+// NewMetricsSourceProvider returns the MetricsSourceProvider for the given
+// type. nameTemplate is only used by MetricsSourcePrometheus, and must
+// contain the literal substring "CONTAINER" wherever the container name
+// should be substituted, e.g. `container_cpu_usage{container="CONTAINER"}`.
+func NewMetricsSourceProvider(t MetricsSourceType, nameTemplate string) (MetricsSourceProvider, error) {
+	switch t {
+	case MetricsSourceDatadog, "":
+		return datadogExternalProvider{}, nil
+	case MetricsSourcePrometheus:
+		if !strings.Contains(nameTemplate, containerNamePlaceholder) {
+			return nil, fmt.Errorf("prometheus-external metrics source requires a name template containing %q", containerNamePlaceholder)
+		}
+		return prometheusExternalProvider{nameTemplate: nameTemplate}, nil
+	case MetricsSourceContainerResource:
+		return containerResourceProvider{}, nil
+	default:
+		return nil, fmt.Errorf("unknown metrics source type: %s", t)
+	}
+}
+
+// datadogExternalProvider is the original, Datadog-Cluster-Agent-backed
+// behavior: External metrics whose name is taken from the
+// HPAContainerBased{CPU,Memory}ExternalMetricNamePrefixAnnotation
+// annotations on the HPA.
+type datadogExternalProvider struct{}
+
+func (datadogExternalProvider) BuildMetricSpec(hpa *v2.HorizontalPodAutoscaler, containerName string, resourceName corev1.ResourceName, targetValue int32) (v2.MetricSpec, error) {
+	name, err := externalMetricNameFromAnnotation(hpa, containerName, resourceName)
+	if err != nil {
+		return v2.MetricSpec{}, err
+	}
+	return v2.MetricSpec{
+		Type: v2.ExternalMetricSourceType,
+		External: &v2.ExternalMetricSource{
+			Metric: v2.MetricIdentifier{Name: name},
+			Target: v2.MetricTarget{
+				Type:  v2.ValueMetricType,
+				Value: resourceQuantityPtr(resource.MustParse(fmt.Sprintf("%d", targetValue))),
+			},
+		},
+	}, nil
+}
+
+func (datadogExternalProvider) UpdateTargetValue(hpa *v2.HorizontalPodAutoscaler, containerName string, resourceName corev1.ResourceName, targetValue int32) error {
+	name, err := externalMetricNameFromAnnotation(hpa, containerName, resourceName)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range hpa.Spec.Metrics {
+		if m.Type != v2.ExternalMetricSourceType || m.External == nil || m.External.Metric.Name != name {
+			continue
+		}
+		m.External.Target.Value.Set(int64(targetValue))
+	}
+
+	return nil
+}
+
+// prometheusExternalProvider targets prometheus-adapter: container
+// utilization is exposed as an External metric computed from a PromQL query
+// templated per-container.
+type prometheusExternalProvider struct {
+	nameTemplate string
+}
+
+func (p prometheusExternalProvider) metricName(containerName string) string {
+	return strings.ReplaceAll(p.nameTemplate, containerNamePlaceholder, containerName)
+}
+
+func (p prometheusExternalProvider) BuildMetricSpec(_ *v2.HorizontalPodAutoscaler, containerName string, _ corev1.ResourceName, targetValue int32) (v2.MetricSpec, error) {
+	return v2.MetricSpec{
+		Type: v2.ExternalMetricSourceType,
+		External: &v2.ExternalMetricSource{
+			Metric: v2.MetricIdentifier{Name: p.metricName(containerName)},
+			Target: v2.MetricTarget{
+				Type:  v2.ValueMetricType,
+				Value: resourceQuantityPtr(resource.MustParse(fmt.Sprintf("%d", targetValue))),
+			},
+		},
+	}, nil
+}
+
+func (p prometheusExternalProvider) UpdateTargetValue(hpa *v2.HorizontalPodAutoscaler, containerName string, _ corev1.ResourceName, targetValue int32) error {
+	name := p.metricName(containerName)
+	for _, m := range hpa.Spec.Metrics {
+		if m.Type != v2.ExternalMetricSourceType || m.External == nil || m.External.Metric.Name != name {
+			continue
+		}
+		m.External.Target.Value.Set(int64(targetValue))
+	}
+	return nil
+}
+
+// containerResourceProvider uses the native, stable
+// v2.ContainerResourceMetricSourceType and needs no metrics pipeline beyond
+// metrics-server.
+type containerResourceProvider struct{}
+
+func (containerResourceProvider) BuildMetricSpec(_ *v2.HorizontalPodAutoscaler, containerName string, resourceName corev1.ResourceName, targetValue int32) (v2.MetricSpec, error) {
+	utilization := targetValue
+	return v2.MetricSpec{
+		Type: v2.ContainerResourceMetricSourceType,
+		ContainerResource: &v2.ContainerResourceMetricSource{
+			Name:      resourceName,
+			Container: containerName,
+			Target: v2.MetricTarget{
+				Type:               v2.UtilizationMetricType,
+				AverageUtilization: &utilization,
+			},
+		},
+	}, nil
+}
+
+func (containerResourceProvider) UpdateTargetValue(hpa *v2.HorizontalPodAutoscaler, containerName string, resourceName corev1.ResourceName, targetValue int32) error {
+	for _, m := range hpa.Spec.Metrics {
+		if m.Type != v2.ContainerResourceMetricSourceType || m.ContainerResource == nil {
+			continue
+		}
+		if m.ContainerResource.Container != containerName || m.ContainerResource.Name != resourceName {
+			continue
+		}
+		utilization := targetValue
+		m.ContainerResource.Target.AverageUtilization = &utilization
+	}
+	return nil
+}
+
+// metricsSourceProviderForTortoise resolves which MetricsSourceProvider a
+// given Tortoise should use: the Tortoise's own spec field wins, falling
+// back to the controller-wide default configured via New().
+func metricsSourceProviderForTortoise(tortoise *autoscalingv1alpha1.Tortoise, controllerDefault MetricsSourceProvider) (MetricsSourceProvider, error) {
+	if tortoise.Spec.MetricsSource == "" {
+		return controllerDefault, nil
+	}
+	return NewMetricsSourceProvider(MetricsSourceType(tortoise.Spec.MetricsSource), tortoise.Spec.MetricsSourcePrometheusNameTemplate)
+}
@@ -0,0 +1,57 @@
+package hpa
+
+import "testing"
+
+func TestNewMetricsSourceProvider(t *testing.T) {
+	cases := map[string]struct {
+		sourceType   MetricsSourceType
+		nameTemplate string
+		wantType     MetricsSourceProvider
+		wantErr      bool
+	}{
+		"empty type defaults to datadog": {
+			sourceType: "",
+			wantType:   datadogExternalProvider{},
+		},
+		"datadog": {
+			sourceType: MetricsSourceDatadog,
+			wantType:   datadogExternalProvider{},
+		},
+		"container resource": {
+			sourceType: MetricsSourceContainerResource,
+			wantType:   containerResourceProvider{},
+		},
+		"prometheus with valid template": {
+			sourceType:   MetricsSourcePrometheus,
+			nameTemplate: `container_cpu_usage{container="CONTAINER"}`,
+			wantType:     prometheusExternalProvider{nameTemplate: `container_cpu_usage{container="CONTAINER"}`},
+		},
+		"prometheus with template missing the CONTAINER placeholder errors": {
+			sourceType:   MetricsSourcePrometheus,
+			nameTemplate: `container_cpu_usage{container="app"}`,
+			wantErr:      true,
+		},
+		"unknown type errors": {
+			sourceType: "something-else",
+			wantErr:    true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := NewMetricsSourceProvider(tc.sourceType, tc.nameTemplate)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("NewMetricsSourceProvider() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewMetricsSourceProvider() error = %v", err)
+			}
+			if got != tc.wantType {
+				t.Errorf("NewMetricsSourceProvider() = %#v, want %#v", got, tc.wantType)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package hpa
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestObserveReconciliationOutcome(t *testing.T) {
+	cases := map[string]struct {
+		err           error
+		alreadyExists bool
+		want          outcome
+	}{
+		"success":                                {err: nil, alreadyExists: false, want: outcomeSuccess},
+		"error":                                  {err: errors.New("boom"), alreadyExists: false, want: outcomeError},
+		"already exists wins over nil error":     {err: nil, alreadyExists: true, want: outcomeAlreadyExists},
+		"already exists wins over an error too":  {err: errors.New("boom"), alreadyExists: true, want: outcomeAlreadyExists},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			namespace, tortoiseName, operation, phase := "ns", "test-"+name, "create", "Working"
+
+			observeReconciliation(namespace, tortoiseName, operation, phase, time.Now(), tc.err, tc.alreadyExists)
+
+			for _, o := range []outcome{outcomeSuccess, outcomeError, outcomeAlreadyExists} {
+				want := 0.0
+				if o == tc.want {
+					want = 1.0
+				}
+				got := testutil.ToFloat64(reconciliationsTotal.WithLabelValues(namespace, tortoiseName, operation, phase, string(o)))
+				if got != want {
+					t.Errorf("reconciliationsTotal{outcome=%s} = %v, want %v", o, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package hpa
+
+import (
+	"encoding/json"
+	"fmt"
+
+	v2 "k8s.io/api/autoscaling/v2"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// lastAppliedAnnotation stores the subset of an HPA's fields that Tortoise
+// itself last wrote (min/max replicas and the target utilizations it set),
+// much like the last-applied-config annotation `kubectl apply` keeps.
+// UpdateHPAFromTortoiseRecommendation reads it only to tell whether the HPA
+// was already initialized; it does not consult the snapshot to decide which
+// fields to write. It writes min/max replicas and the target values of the
+// metrics it has recommendations for, and leaves other metrics and the
+// Behavior stanza as they are, except for ScaleDown.SelectPolicy, which the
+// emergency override sets and later clears.
+const lastAppliedAnnotation = "autoscaling.mercari.com/last-applied-by-tortoise"
+
+// adoptedAnnotation marks that Tortoise has registered itself as a co-owner
+// of a pre-existing HPA it didn't create. It's deliberately separate from
+// lastAppliedAnnotation: adoption just means Tortoise is now allowed to
+// manage the HPA, not that it has ever written a real recommendation to it.
+// Stamping lastAppliedAnnotation at adopt time would make
+// UpdateHPAFromTortoiseRecommendation's TortoiseUpdateModeInitialize check
+// think the HPA was already initialized and skip seeding it entirely.
+const adoptedAnnotation = "autoscaling.mercari.com/adopted-by-tortoise"
+
+// managedSubset is the content of lastAppliedAnnotation. TargetUtilizations
+// is keyed by "<containerName>/<resourceName>" so it round-trips through
+// JSON without a nested map-of-maps.
+type managedSubset struct {
+	MinReplicas        int32            `json:"minReplicas"`
+	MaxReplicas        int32            `json:"maxReplicas"`
+	TargetUtilizations map[string]int32 `json:"targetUtilizations,omitempty"`
+}
+
+func managedTargetUtilizationKey(containerName string, resourceName corev1.ResourceName) string {
+	return containerName + "/" + string(resourceName)
+}
+
+// lastAppliedByTortoise reads back the subset of hpa that Tortoise last
+// wrote, or ok=false if the HPA has never been reconciled by Tortoise (e.g.
+// it's a pre-existing, user-managed HPA Tortoise has just adopted).
+func lastAppliedByTortoise(hpa *v2.HorizontalPodAutoscaler) (managedSubset, bool, error) {
+	raw, ok := hpa.GetAnnotations()[lastAppliedAnnotation]
+	if !ok {
+		return managedSubset{}, false, nil
+	}
+
+	var s managedSubset
+	if err := json.Unmarshal([]byte(raw), &s); err != nil {
+		return managedSubset{}, false, fmt.Errorf("unmarshal %s annotation: %w", lastAppliedAnnotation, err)
+	}
+	return s, true, nil
+}
+
+// adoptedByTortoise reports whether hpa already carries adoptedAnnotation.
+func adoptedByTortoise(hpa *v2.HorizontalPodAutoscaler) bool {
+	return hpa.GetAnnotations()[adoptedAnnotation] == "true"
+}
+
+// recordAdopted stamps adoptedAnnotation on hpa, registering Tortoise as a
+// co-owner without touching lastAppliedAnnotation.
+func recordAdopted(hpa *v2.HorizontalPodAutoscaler) {
+	if hpa.Annotations == nil {
+		hpa.Annotations = map[string]string{}
+	}
+	hpa.Annotations[adoptedAnnotation] = "true"
+}
+
+// recordLastApplied snapshots the fields Tortoise just wrote to hpa into
+// the lastAppliedAnnotation, so a later reconcile can tell that Tortoise has
+// already written to this HPA.
+func recordLastApplied(hpa *v2.HorizontalPodAutoscaler, min, max int32, targetUtilizations map[string]int32) error {
+	s := managedSubset{
+		MinReplicas:        min,
+		MaxReplicas:        max,
+		TargetUtilizations: targetUtilizations,
+	}
+
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("marshal %s annotation: %w", lastAppliedAnnotation, err)
+	}
+
+	if hpa.Annotations == nil {
+		hpa.Annotations = map[string]string{}
+	}
+	hpa.Annotations[lastAppliedAnnotation] = string(raw)
+	return nil
+}
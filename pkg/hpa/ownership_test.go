@@ -0,0 +1,74 @@
+package hpa
+
+import (
+	"testing"
+
+	v2 "k8s.io/api/autoscaling/v2"
+)
+
+func TestAdoptedByTortoise(t *testing.T) {
+	cases := map[string]struct {
+		annotations map[string]string
+		want        bool
+	}{
+		"no annotations":      {annotations: nil, want: false},
+		"annotated elsewhere": {annotations: map[string]string{lastAppliedAnnotation: `{"minReplicas":1,"maxReplicas":2}`}, want: false},
+		"adopted":             {annotations: map[string]string{adoptedAnnotation: "true"}, want: true},
+		"garbage value":       {annotations: map[string]string{adoptedAnnotation: "yes"}, want: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			hpa := &v2.HorizontalPodAutoscaler{}
+			hpa.Annotations = tc.annotations
+			if got := adoptedByTortoise(hpa); got != tc.want {
+				t.Errorf("adoptedByTortoise() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestRecordAdoptedDoesNotInitialize guards the bug the adopt flow used to
+// have: recordAdopted must never make lastAppliedByTortoise report ok=true,
+// or TortoiseUpdateModeInitialize would treat a freshly-adopted HPA as
+// already seeded and skip writing the real recommendation to it.
+func TestRecordAdoptedDoesNotInitialize(t *testing.T) {
+	hpa := &v2.HorizontalPodAutoscaler{}
+
+	recordAdopted(hpa)
+
+	if !adoptedByTortoise(hpa) {
+		t.Fatal("recordAdopted did not mark the HPA as adopted")
+	}
+
+	_, alreadyInitialized, err := lastAppliedByTortoise(hpa)
+	if err != nil {
+		t.Fatalf("lastAppliedByTortoise() error = %v", err)
+	}
+	if alreadyInitialized {
+		t.Fatal("recordAdopted must not make lastAppliedByTortoise report the HPA as already initialized")
+	}
+}
+
+func TestRecordLastAppliedRoundTrips(t *testing.T) {
+	hpa := &v2.HorizontalPodAutoscaler{}
+	targetUtilizations := map[string]int32{"app/cpu": 50}
+
+	if err := recordLastApplied(hpa, 2, 10, targetUtilizations); err != nil {
+		t.Fatalf("recordLastApplied() error = %v", err)
+	}
+
+	got, ok, err := lastAppliedByTortoise(hpa)
+	if err != nil {
+		t.Fatalf("lastAppliedByTortoise() error = %v", err)
+	}
+	if !ok {
+		t.Fatal("lastAppliedByTortoise() ok = false, want true")
+	}
+	if got.MinReplicas != 2 || got.MaxReplicas != 10 {
+		t.Errorf("lastAppliedByTortoise() = %+v, want MinReplicas=2 MaxReplicas=10", got)
+	}
+	if got.TargetUtilizations["app/cpu"] != 50 {
+		t.Errorf("lastAppliedByTortoise() TargetUtilizations = %v, want app/cpu=50", got.TargetUtilizations)
+	}
+}
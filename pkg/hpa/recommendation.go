@@ -0,0 +1,111 @@
+package hpa
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	autoscalingv1alpha1 "github.com/mercari/tortoise/api/v1alpha1"
+)
+
+// getReplicasRecommendation finds the slot that applies to now. It no
+// longer fails the whole reconcile the moment a single slot is missing: a
+// transient CRD edit that leaves a gap in the schedule shouldn't take down
+// autoscaling. Instead it falls back, in order:
+//  1. the exact [From,To) slot on now.Weekday(),
+//  2. the nearest earlier slot on the same weekday,
+//  3. a slot covering now.Hour() on any other weekday,
+//  4. lastApplied, the last value tortoise actually wrote to the HPA.
+//
+// It only returns an error if none of those exist, e.g. on a Tortoise's
+// very first reconcile with an incomplete schedule and no history yet.
+func getReplicasRecommendation(recommendations []autoscalingv1alpha1.ReplicasRecommendation, now time.Time, lastApplied *int32) (int32, error) {
+	for _, r := range recommendations {
+		if now.Hour() < r.To && now.Hour() >= r.From && now.Weekday() == r.WeekDay {
+			return r.Value, nil
+		}
+	}
+
+	if v, ok := nearestPreviousSlotSameWeekday(recommendations, now); ok {
+		return v, nil
+	}
+
+	if v, ok := sameHourOtherWeekday(recommendations, now); ok {
+		return v, nil
+	}
+
+	if lastApplied != nil {
+		return *lastApplied, nil
+	}
+
+	return 0, errors.New("no recommendation slot")
+}
+
+func nearestPreviousSlotSameWeekday(recommendations []autoscalingv1alpha1.ReplicasRecommendation, now time.Time) (int32, bool) {
+	best := -1
+	var value int32
+	for _, r := range recommendations {
+		if r.WeekDay != now.Weekday() || r.To > now.Hour() {
+			continue
+		}
+		if r.To > best {
+			best = r.To
+			value = r.Value
+		}
+	}
+	return value, best >= 0
+}
+
+func sameHourOtherWeekday(recommendations []autoscalingv1alpha1.ReplicasRecommendation, now time.Time) (int32, bool) {
+	for _, r := range recommendations {
+		if now.Hour() < r.To && now.Hour() >= r.From {
+			return r.Value, true
+		}
+	}
+	return 0, false
+}
+
+// ValidateReplicasRecommendationSlots checks that recommendations has no
+// gaps and no overlaps, and covers all 24 hours of all 7 weekdays; an empty
+// schedule is accepted as well. It's
+// meant to be called from the Tortoise validating webhook at admission
+// time, so a mis-configured schedule is rejected up front instead of
+// silently falling back at runtime.
+//
+// TODO: wire this into an actual validating webhook; that's a follow-up to
+// this change. Until then, getReplicasRecommendation's runtime fallback
+// chain is what actually protects a reconcile from a bad schedule.
+func ValidateReplicasRecommendationSlots(recommendations []autoscalingv1alpha1.ReplicasRecommendation) error {
+	covered := make(map[time.Weekday][24]bool)
+
+	for _, r := range recommendations {
+		if r.From < 0 || r.To > 24 || r.From >= r.To {
+			return fmt.Errorf("invalid slot %s %d-%d: From must be < To, within [0,24]", r.WeekDay, r.From, r.To)
+		}
+
+		hours := covered[r.WeekDay]
+		for h := r.From; h < r.To; h++ {
+			if hours[h] {
+				return fmt.Errorf("overlapping recommendation slots at %s %d:00", r.WeekDay, h)
+			}
+			hours[h] = true
+		}
+		covered[r.WeekDay] = hours
+	}
+
+	if len(recommendations) == 0 {
+		// No schedule at all is allowed; callers fall back to lastApplied.
+		return nil
+	}
+
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		hours := covered[weekday]
+		for h := 0; h < 24; h++ {
+			if !hours[h] {
+				return fmt.Errorf("missing recommendation slot at %s %d:00", weekday, h)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package hpa
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/utils/pointer"
+
+	autoscalingv1alpha1 "github.com/mercari/tortoise/api/v1alpha1"
+)
+
+func TestGetReplicasRecommendation(t *testing.T) {
+	// Wednesday 2024-01-03.
+	wed10am := time.Date(2024, time.January, 3, 10, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		recommendations []autoscalingv1alpha1.ReplicasRecommendation
+		now             time.Time
+		lastApplied     *int32
+		want            int32
+		wantErr         bool
+	}{
+		"exact slot": {
+			recommendations: []autoscalingv1alpha1.ReplicasRecommendation{
+				{From: 9, To: 11, WeekDay: time.Wednesday, Value: 5},
+			},
+			now:  wed10am,
+			want: 5,
+		},
+		"falls back to nearest previous slot same weekday": {
+			recommendations: []autoscalingv1alpha1.ReplicasRecommendation{
+				{From: 0, To: 8, WeekDay: time.Wednesday, Value: 3},
+				// gap from 8-12
+				{From: 12, To: 18, WeekDay: time.Wednesday, Value: 7},
+			},
+			now:  wed10am,
+			want: 3,
+		},
+		"falls back to same hour other weekday when no earlier slot exists": {
+			recommendations: []autoscalingv1alpha1.ReplicasRecommendation{
+				{From: 9, To: 11, WeekDay: time.Thursday, Value: 9},
+			},
+			now:  wed10am,
+			want: 9,
+		},
+		"falls back to lastApplied when schedule has no usable slot": {
+			recommendations: []autoscalingv1alpha1.ReplicasRecommendation{},
+			now:             wed10am,
+			lastApplied:     pointer.Int32(4),
+			want:            4,
+		},
+		"errors when nothing applies and no history": {
+			recommendations: []autoscalingv1alpha1.ReplicasRecommendation{},
+			now:             wed10am,
+			lastApplied:     nil,
+			wantErr:         true,
+		},
+		"nearest previous slot same weekday wins over same hour other weekday": {
+			recommendations: []autoscalingv1alpha1.ReplicasRecommendation{
+				{From: 0, To: 8, WeekDay: time.Wednesday, Value: 3},
+				{From: 9, To: 11, WeekDay: time.Thursday, Value: 9},
+			},
+			now:  wed10am,
+			want: 3,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := getReplicasRecommendation(tc.recommendations, tc.now, tc.lastApplied)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("getReplicasRecommendation() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("getReplicasRecommendation() error = %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("getReplicasRecommendation() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNearestPreviousSlotSameWeekday(t *testing.T) {
+	wed10am := time.Date(2024, time.January, 3, 10, 0, 0, 0, time.UTC)
+
+	recommendations := []autoscalingv1alpha1.ReplicasRecommendation{
+		{From: 0, To: 4, WeekDay: time.Wednesday, Value: 1},
+		{From: 4, To: 8, WeekDay: time.Wednesday, Value: 2},
+		{From: 8, To: 20, WeekDay: time.Tuesday, Value: 99}, // wrong weekday, ignored
+	}
+
+	got, ok := nearestPreviousSlotSameWeekday(recommendations, wed10am)
+	if !ok {
+		t.Fatal("nearestPreviousSlotSameWeekday() ok = false, want true")
+	}
+	if got != 2 {
+		t.Errorf("nearestPreviousSlotSameWeekday() = %d, want 2 (the later of the two earlier slots)", got)
+	}
+
+	_, ok = nearestPreviousSlotSameWeekday(nil, wed10am)
+	if ok {
+		t.Error("nearestPreviousSlotSameWeekday() ok = true for empty input, want false")
+	}
+}
+
+func TestSameHourOtherWeekday(t *testing.T) {
+	wed10am := time.Date(2024, time.January, 3, 10, 0, 0, 0, time.UTC)
+
+	got, ok := sameHourOtherWeekday([]autoscalingv1alpha1.ReplicasRecommendation{
+		{From: 9, To: 11, WeekDay: time.Monday, Value: 6},
+	}, wed10am)
+	if !ok || got != 6 {
+		t.Errorf("sameHourOtherWeekday() = (%d, %v), want (6, true)", got, ok)
+	}
+
+	_, ok = sameHourOtherWeekday([]autoscalingv1alpha1.ReplicasRecommendation{
+		{From: 12, To: 14, WeekDay: time.Monday, Value: 6},
+	}, wed10am)
+	if ok {
+		t.Error("sameHourOtherWeekday() ok = true for a slot that doesn't cover the hour, want false")
+	}
+}
+
+// fullWeekSchedule returns one [0,24) slot per weekday, the minimal input
+// that satisfies ValidateReplicasRecommendationSlots' full-coverage check.
+func fullWeekSchedule() []autoscalingv1alpha1.ReplicasRecommendation {
+	var recommendations []autoscalingv1alpha1.ReplicasRecommendation
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		recommendations = append(recommendations, autoscalingv1alpha1.ReplicasRecommendation{From: 0, To: 24, WeekDay: weekday, Value: 1})
+	}
+	return recommendations
+}
+
+func TestValidateReplicasRecommendationSlots(t *testing.T) {
+	cases := map[string]struct {
+		recommendations []autoscalingv1alpha1.ReplicasRecommendation
+		wantErr         bool
+	}{
+		"empty schedule is allowed": {
+			recommendations: nil,
+		},
+		"full week of single all-day slots": {
+			recommendations: fullWeekSchedule(),
+		},
+		"full week split into two half-day slots per weekday": {
+			recommendations: func() []autoscalingv1alpha1.ReplicasRecommendation {
+				var recommendations []autoscalingv1alpha1.ReplicasRecommendation
+				for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+					recommendations = append(recommendations,
+						autoscalingv1alpha1.ReplicasRecommendation{From: 0, To: 12, WeekDay: weekday, Value: 1},
+						autoscalingv1alpha1.ReplicasRecommendation{From: 12, To: 24, WeekDay: weekday, Value: 2},
+					)
+				}
+				return recommendations
+			}(),
+		},
+		"invalid slot where From >= To": {
+			recommendations: []autoscalingv1alpha1.ReplicasRecommendation{
+				{From: 10, To: 10, WeekDay: time.Monday, Value: 1},
+			},
+			wantErr: true,
+		},
+		"invalid slot out of [0,24) range": {
+			recommendations: []autoscalingv1alpha1.ReplicasRecommendation{
+				{From: 0, To: 25, WeekDay: time.Monday, Value: 1},
+			},
+			wantErr: true,
+		},
+		"overlapping slots on the same weekday": {
+			recommendations: []autoscalingv1alpha1.ReplicasRecommendation{
+				{From: 0, To: 12, WeekDay: time.Monday, Value: 1},
+				{From: 8, To: 20, WeekDay: time.Monday, Value: 2},
+			},
+			wantErr: true,
+		},
+		"gap leaves a weekday only partially covered": {
+			recommendations: []autoscalingv1alpha1.ReplicasRecommendation{
+				{From: 0, To: 12, WeekDay: time.Monday, Value: 1},
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateReplicasRecommendationSlots(tc.recommendations)
+			if tc.wantErr && err == nil {
+				t.Fatal("ValidateReplicasRecommendationSlots() error = nil, want error")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("ValidateReplicasRecommendationSlots() error = %v", err)
+			}
+		})
+	}
+}
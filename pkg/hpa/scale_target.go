@@ -0,0 +1,78 @@
+package hpa
+
+import (
+	"context"
+	"fmt"
+
+	v2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/scale"
+)
+
+// ScaleTarget describes the workload an HPA should scale, generalizing
+// beyond Deployments to anything exposing the /scale subresource
+// (StatefulSet, ReplicaSet, or a CRD) — the same way the upstream HPA
+// controller resolves hpa.Spec.ScaleTargetRef via a RESTMapper and a
+// scale.ScalesGetter instead of assuming a Deployment.
+type ScaleTarget struct {
+	GroupVersionKind schema.GroupVersionKind
+	Name             string
+	// CurrentReplicas is read from the target's /scale subresource (e.g.
+	// scale.Status.Replicas), not from a Deployment-specific field.
+	CurrentReplicas int32
+}
+
+// crossVersionObjectReference converts the ScaleTarget into the
+// CrossVersionObjectReference the HPA spec embeds.
+func (t ScaleTarget) crossVersionObjectReference() v2.CrossVersionObjectReference {
+	return v2.CrossVersionObjectReference{
+		Kind:       t.GroupVersionKind.Kind,
+		APIVersion: t.GroupVersionKind.GroupVersion().String(),
+		Name:       t.Name,
+	}
+}
+
+// DeploymentGVK and StatefulSetGVK are the two built-in workload kinds
+// ScaleTargetGetter.Get resolves most often; pass any other GroupVersionKind
+// that has a /scale subresource (e.g. a CRD) the same way.
+var (
+	DeploymentGVK  = schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	StatefulSetGVK = schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"}
+)
+
+// ScaleTargetGetter resolves a ScaleTarget's CurrentReplicas by reading the
+// workload's /scale subresource, so a Tortoise can scale a StatefulSet or a
+// scale-enabled CRD exactly like a Deployment instead of requiring the
+// caller to already know how to read that workload's replica count.
+type ScaleTargetGetter struct {
+	scales scale.ScalesGetter
+	mapper meta.RESTMapper
+}
+
+// NewScaleTargetGetter builds a ScaleTargetGetter. scales and mapper are
+// typically scale.New(...) and the manager's RESTMapper, respectively.
+func NewScaleTargetGetter(scales scale.ScalesGetter, mapper meta.RESTMapper) *ScaleTargetGetter {
+	return &ScaleTargetGetter{scales: scales, mapper: mapper}
+}
+
+// Get fetches the current ScaleTarget for the workload identified by gvk and
+// name in namespace, via the /scale subresource.
+func (g *ScaleTargetGetter) Get(ctx context.Context, namespace string, gvk schema.GroupVersionKind, name string) (ScaleTarget, error) {
+	mapping, err := g.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return ScaleTarget{}, fmt.Errorf("map %s to a resource: %w", gvk, err)
+	}
+
+	s, err := g.scales.Scales(namespace).Get(ctx, mapping.Resource.GroupResource(), name, metav1.GetOptions{})
+	if err != nil {
+		return ScaleTarget{}, fmt.Errorf("get /scale subresource for %s %s/%s: %w", gvk.Kind, namespace, name, err)
+	}
+
+	return ScaleTarget{
+		GroupVersionKind: gvk,
+		Name:             name,
+		CurrentReplicas:  s.Status.Replicas,
+	}, nil
+}
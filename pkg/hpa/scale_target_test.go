@@ -0,0 +1,39 @@
+package hpa
+
+import (
+	"testing"
+
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestScaleTargetCrossVersionObjectReference(t *testing.T) {
+	cases := map[string]struct {
+		target ScaleTarget
+		want   v2.CrossVersionObjectReference
+	}{
+		"deployment": {
+			target: ScaleTarget{GroupVersionKind: DeploymentGVK, Name: "my-app"},
+			want:   v2.CrossVersionObjectReference{Kind: "Deployment", APIVersion: "apps/v1", Name: "my-app"},
+		},
+		"statefulset": {
+			target: ScaleTarget{GroupVersionKind: StatefulSetGVK, Name: "my-statefulset"},
+			want:   v2.CrossVersionObjectReference{Kind: "StatefulSet", APIVersion: "apps/v1", Name: "my-statefulset"},
+		},
+		"custom resource with a grouped gvk": {
+			target: ScaleTarget{
+				GroupVersionKind: schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"},
+				Name:             "my-widget",
+			},
+			want: v2.CrossVersionObjectReference{Kind: "Widget", APIVersion: "example.com/v1", Name: "my-widget"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.target.crossVersionObjectReference(); got != tc.want {
+				t.Errorf("crossVersionObjectReference() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}